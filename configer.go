@@ -2,10 +2,13 @@ package configer
 
 import (
 	"fmt"
-	"os"
+	"io"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 )
 
@@ -23,29 +26,107 @@ type Configer interface {
 	Duration(key string) time.Duration
 	Time(key string) time.Time
 	Exists(key string) bool
+
+	// Watch starts watching the default and (if present) env-specific config
+	// files for changes, reloading values and firing OnChange callbacks as
+	// they're edited. It is a no-op if a watch is already running.
+	Watch() error
+	// OnChange registers a callback invoked with the old and new values of
+	// every key that changes on a reload triggered by Watch. newVal is nil
+	// when a key was removed.
+	OnChange(fn ChangeHandler)
+	// Close stops any running watch. It is safe to call even if Watch was
+	// never called.
+	Close() error
+
+	// Unmarshal decodes the whole config into target, a pointer to a struct.
+	// See UnmarshalOption for decode-time customization.
+	Unmarshal(target any, opts ...UnmarshalOption) error
+	// UnmarshalKey decodes the value at key into target, a pointer to a
+	// struct, with the same semantics as Unmarshal.
+	UnmarshalKey(key string, target any, opts ...UnmarshalOption) error
+
+	// ConfigFileUsed returns the path of the config file viper actually
+	// loaded (the base file resolved by WithConfigName/WithConfigPaths, or
+	// the legacy prefix+type path), or "" when config came only from
+	// WithConfigBuffer, defaults, or env/remote/flag sources.
+	ConfigFileUsed() string
+
+	// Sources returns the WithLayers layer names that defined key, in the
+	// order they were merged; the last entry is the layer the current value
+	// came from. Returns nil for keys not sourced from a layer file (e.g.
+	// set only via env var, flag, or struct default), or when WithLayers
+	// wasn't used.
+	Sources(key string) []string
 }
 
 type configer struct {
+	mu    sync.RWMutex
 	viper *viper.Viper
+
+	// cfg, defaultFile and envFile are retained so Watch can rebuild the
+	// viper instance from scratch on every reload.
+	cfg         *config
+	defaultFile string
+	envFile     string
+
+	watcher  *fsnotify.Watcher
+	onChange []ChangeHandler
+	closed   chan struct{}
+
+	// remoteViper holds the remote-only view of config when a remote
+	// provider is configured, so it can be re-polled without re-reading the
+	// file-based config.
+	remoteViper  *viper.Viper
+	remoteClosed chan struct{}
+
+	// layerFiles holds the files actually loaded for a WithLayers config, in
+	// layer order, so Watch can observe all of them and Sources can report
+	// provenance. Empty when WithLayers isn't used.
+	layerFiles []string
+	sources    map[string][]string
 }
 
 // config holds internal configuration options for building the Configer.
 type config struct {
-	configFileType   string
-	configFilePrefix string
-	envVarName       string
-	autoEnv          bool
-	envPrefix        string
-	bindEnvKeys      []string
+	configFileType    string
+	configFileTypeSet bool
+	configFilePrefix  string
+	envVarName        string
+	autoEnv           bool
+	envPrefix         string
+	bindEnvKeys       []string
+	schema            any
+
+	remoteProvider      string
+	remoteEndpoint      string
+	remotePath          string
+	remoteSecretKeyring string
+	remotePollInterval  time.Duration
+
+	pflags      *pflag.FlagSet
+	dotEnvPaths []string
+
+	configPaths []string
+	configName  string
+
+	configBuffer       io.Reader
+	configBufferFormat string
+
+	layers         []string
+	requiredLayers []string
 }
 
 // option is a functional option for configuring the Configer.
 type option func(*config)
 
 // WithConfigFileType sets the configuration file type (e.g., "toml", "yaml").
+// With WithConfigPaths/WithConfigName it pins the format instead of letting
+// viper auto-detect it from the discovered file's extension.
 func WithConfigFileType(t string) option {
 	return func(c *config) {
 		c.configFileType = t
+		c.configFileTypeSet = true
 	}
 }
 
@@ -88,14 +169,129 @@ func WithBindEnv(keys ...string) option {
 	}
 }
 
+// WithSchema has New unmarshal the loaded config into schema (a pointer to a
+// struct) and validate it via its `validate:"..."` tags, returning an
+// aggregated error if New would otherwise succeed but the config is invalid.
+func WithSchema(schema any) option {
+	return func(c *config) {
+		c.schema = schema
+	}
+}
+
 func defaults() *config {
 	return &config{
-		configFileType:   "toml",
-		configFilePrefix: "config/",
-		envVarName:       "ENV",
-		autoEnv:          true,
-		envPrefix:        "",
-		bindEnvKeys:      []string{},
+		configFileType:     "toml",
+		configFilePrefix:   "config/",
+		envVarName:         "ENV",
+		autoEnv:            true,
+		envPrefix:          "",
+		bindEnvKeys:        []string{},
+		remotePollInterval: 30 * time.Second,
+	}
+}
+
+// WithRemoteProvider configures a remote config source (e.g. "etcd3" or
+// "consul") that New reads via viper's remote provider support and merges
+// over the file-based config. provider and endpoint match viper's
+// AddRemoteProvider arguments; path is the key/prefix to read.
+func WithRemoteProvider(provider, endpoint, path string) option {
+	return func(c *config) {
+		c.remoteProvider = provider
+		c.remoteEndpoint = endpoint
+		c.remotePath = path
+	}
+}
+
+// WithRemoteSecretKeyring sets the path to a PGP keyring used to decrypt an
+// encrypted remote config, switching New to viper's AddSecureRemoteProvider.
+// Only meaningful alongside WithRemoteProvider.
+func WithRemoteSecretKeyring(path string) option {
+	return func(c *config) {
+		c.remoteSecretKeyring = path
+	}
+}
+
+// WithRemotePollInterval sets how often the background poll that New starts
+// for a WithRemoteProvider config re-reads the remote provider. Defaults to
+// 30s.
+func WithRemotePollInterval(d time.Duration) option {
+	return func(c *config) {
+		c.remotePollInterval = d
+	}
+}
+
+// WithPFlags binds a cobra/pflag FlagSet so explicitly-set flags take
+// precedence over every other source, matching viper's own flag/env/config
+// precedence. Flags left at their default value still fall through to env
+// vars and config files.
+func WithPFlags(fs *pflag.FlagSet) option {
+	return func(c *config) {
+		c.pflags = fs
+	}
+}
+
+// WithDotEnv loads the given .env files (in order) into the process
+// environment before config files and env binding are applied, so their
+// values are visible to WithEnvVarName, WithAutomaticEnv and WithBindEnv.
+// Variables already set in the environment are left untouched.
+func WithDotEnv(paths ...string) option {
+	return func(c *config) {
+		c.dotEnvPaths = paths
+	}
+}
+
+// WithConfigPaths sets the directories viper searches for the config file,
+// replacing the single WithEnvConfigFilePrefix directory. Use alongside
+// WithConfigName to let viper auto-detect the file format from any of its
+// SupportedExts (json, yaml, yml, toml, hcl, ini, properties, envfile)
+// instead of a fixed WithConfigFileType.
+func WithConfigPaths(paths ...string) option {
+	return func(c *config) {
+		c.configPaths = paths
+	}
+}
+
+// WithConfigName sets the base config file name (without extension) that
+// New searches WithConfigPaths for, e.g. "default" to match default.yaml,
+// default.toml, etc. Only meaningful alongside WithConfigPaths.
+func WithConfigName(name string) option {
+	return func(c *config) {
+		c.configName = name
+	}
+}
+
+// WithConfigBuffer has New read config from r instead of any file, useful
+// for tests and embedded binaries. format is one of viper's SupportedExts
+// (e.g. "yaml", "json"). It takes precedence over WithConfigPaths and the
+// legacy WithConfigFileType/WithEnvConfigFilePrefix file loading, and is not
+// compatible with Watch since there's no file to watch.
+func WithConfigBuffer(r io.Reader, format string) option {
+	return func(c *config) {
+		c.configBuffer = r
+		c.configBufferFormat = format
+	}
+}
+
+// WithLayers replaces the single default/env-specific file merge with an
+// ordered list of named layers: each later layer overrides keys set by
+// earlier ones. A layer name of "${ENV}" expands to the lowercased value of
+// the env var configured by WithEnvVarName, and that layer is skipped
+// entirely if the env var isn't set. Any other layer whose file is missing
+// is also skipped, unless it's named in WithRequiredLayers. Takes
+// precedence over WithConfigPaths/WithConfigName and the legacy
+// WithConfigFileType/WithEnvConfigFilePrefix loading.
+func WithLayers(names ...string) option {
+	return func(c *config) {
+		c.layers = names
+	}
+}
+
+// WithRequiredLayers marks the given layer names (as passed to WithLayers,
+// before "${ENV}" expansion) as mandatory: New returns an error if the
+// layer's file can't be found, instead of silently skipping it.
+func WithRequiredLayers(names ...string) option {
+	return func(c *config) {
+		c.requiredLayers = names
 	}
 }
 
@@ -104,87 +300,174 @@ func defaults() *config {
 // binds env vars (automatic or explicit), and applies other configurations.
 // Returns an error on failure instead of panicking.
 func New(opts ...option) (Configer, error) {
-	v := viper.New()
-
-	config := defaults()
+	cfg := defaults()
 	for _, opt := range opts {
-		opt(config)
+		opt(cfg)
 	}
 
-	v.SetConfigType(config.configFileType)
-	v.SetConfigFile(config.configFilePrefix + "default." + config.configFileType)
+	v, defaultFile, envFile, layerFiles, sources, remoteViper, err := buildViper(cfg)
+	if err != nil {
+		return nil, err
+	}
 
-	if err := v.ReadInConfig(); err != nil {
-		return nil, fmt.Errorf("failed to load default config file: %w", err)
+	c := &configer{
+		viper:       v,
+		cfg:         cfg,
+		defaultFile: defaultFile,
+		envFile:     envFile,
+		layerFiles:  layerFiles,
+		sources:     sources,
+		remoteViper: remoteViper,
 	}
 
-	if value, ok := os.LookupEnv(config.envVarName); ok {
-		envFile := config.configFilePrefix + strings.ToLower(value) + "." + config.configFileType
-		v.SetConfigFile(envFile)
-		if err := v.MergeInConfig(); err != nil {
-			if _, notFound := err.(viper.ConfigFileNotFoundError); !notFound {
-				return nil, fmt.Errorf("failed to load env config file %s: %w", envFile, err)
-			}
+	if cfg.schema != nil {
+		if err := c.Unmarshal(cfg.schema, WithValidate()); err != nil {
+			return nil, fmt.Errorf("config failed schema validation: %w", err)
 		}
 	}
 
-	if config.envPrefix != "" {
-		v.SetEnvPrefix(config.envPrefix)
+	if remoteViper != nil {
+		c.startRemoteWatch()
 	}
 
-	for _, key := range config.bindEnvKeys {
+	return c, nil
+}
+
+// buildViper loads a fresh viper instance from cfg: the default config file,
+// merged with the env-specific file (if the env var it's keyed on is set),
+// merged with a remote provider (if configured), followed by env var
+// binding. It's shared by New and by Watch's reload path so both build the
+// exact same precedence. remoteViper is non-nil only when cfg configures a
+// remote provider, and is kept around so Watch can poll it for changes.
+func buildViper(cfg *config) (v *viper.Viper, defaultFile, envFile string, layerFiles []string, sources map[string][]string, remoteViper *viper.Viper, err error) {
+	if len(cfg.dotEnvPaths) > 0 {
+		if err := loadDotEnv(cfg.dotEnvPaths); err != nil {
+			return nil, "", "", nil, nil, nil, err
+		}
+	}
+
+	v = viper.New()
+
+	defaultFile, envFile, layerFiles, sources, err = loadConfigLayers(v, cfg)
+	if err != nil {
+		return nil, "", "", nil, nil, nil, err
+	}
+
+	remoteViper, err = applyRemoteConfig(v, cfg)
+	if err != nil {
+		return nil, "", "", nil, nil, nil, err
+	}
+
+	if cfg.pflags != nil {
+		if err := v.BindPFlags(cfg.pflags); err != nil {
+			return nil, "", "", nil, nil, nil, fmt.Errorf("failed to bind pflags: %w", err)
+		}
+	}
+
+	if cfg.envPrefix != "" {
+		v.SetEnvPrefix(cfg.envPrefix)
+	}
+
+	for _, key := range cfg.bindEnvKeys {
 		if err := v.BindEnv(key); err != nil {
-			return nil, fmt.Errorf("failed to bind env for key %s: %w", key, err)
+			return nil, "", "", nil, nil, nil, fmt.Errorf("failed to bind env for key %s: %w", key, err)
 		}
 	}
 
-	if config.autoEnv {
+	if cfg.autoEnv {
 		v.AutomaticEnv()
+		// viper.Unmarshal (and friends) only consult AutomaticEnv for keys
+		// it already knows about, so an env var can silently fail to
+		// override a key that's only set via an unset flag default. Binding
+		// every known key explicitly closes that gap, and the replacer
+		// ensures dashes in flag names (e.g. "log-level") map to the
+		// underscored env var form (LOG_LEVEL) rather than being looked up
+		// literally.
+		v.SetEnvKeyReplacer(strings.NewReplacer("-", "_", ".", "_"))
+		for _, key := range v.AllKeys() {
+			if err := v.BindEnv(key); err != nil {
+				return nil, "", "", nil, nil, nil, fmt.Errorf("failed to bind env for key %s: %w", key, err)
+			}
+		}
 	}
 
-	return &configer{viper: v}, nil
+	return v, defaultFile, envFile, layerFiles, sources, remoteViper, nil
 }
 
 func (c *configer) Bool(key string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 	return c.viper.GetBool(key)
 }
 
 func (c *configer) Int(key string) int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 	return c.viper.GetInt(key)
 }
 
 func (c *configer) Int64(key string) int64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 	return c.viper.GetInt64(key)
 }
 
 func (c *configer) Uint(key string) uint {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 	return c.viper.GetUint(key)
 }
 
 func (c *configer) Float64(key string) float64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 	return c.viper.GetFloat64(key)
 }
 
 func (c *configer) String(key string) string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 	return c.viper.GetString(key)
 }
 
 func (c *configer) Strings(key string) []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 	return c.viper.GetStringSlice(key)
 }
 
 func (c *configer) StringMap(key string) map[string]interface{} {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 	return c.viper.GetStringMap(key)
 }
 
 func (c *configer) Duration(key string) time.Duration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 	return c.viper.GetDuration(key)
 }
 
 func (c *configer) Time(key string) time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 	return c.viper.GetTime(key)
 }
 
 func (c *configer) Exists(key string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 	return c.viper.IsSet(key)
 }
+
+func (c *configer) ConfigFileUsed() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.defaultFile
+}
+
+func (c *configer) Sources(key string) []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.sources[key]
+}
@@ -0,0 +1,16 @@
+package configer
+
+import (
+	"fmt"
+
+	"github.com/joho/godotenv"
+)
+
+// loadDotEnv loads each of paths into the process environment via godotenv,
+// without overriding variables the process already has set.
+func loadDotEnv(paths []string) error {
+	if err := godotenv.Load(paths...); err != nil {
+		return fmt.Errorf("failed to load .env file(s): %w", err)
+	}
+	return nil
+}
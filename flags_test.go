@@ -0,0 +1,91 @@
+package configer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/pflag"
+)
+
+// TestWithPFlagsPrecedence exercises the documented precedence rule:
+// "explicit flag > env var > env-specific config file > default config
+// file". An unset flag must fall through to the config value, and an
+// explicitly-set flag must win over both the config and an env var bound
+// over the same key.
+func TestWithPFlagsPrecedence(t *testing.T) {
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	fs.String("key", "flag-default", "")
+
+	c, err := New(
+		WithConfigBuffer(strings.NewReader("key: from-file\n"), "yaml"),
+		WithAutomaticEnv(),
+		WithPFlags(fs),
+	)
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	if got := c.String("key"); got != "from-file" {
+		t.Fatalf("String(%q) with an unset flag = %q, want %q (should fall through to the config file)", "key", got, "from-file")
+	}
+
+	t.Setenv("KEY", "from-env")
+	fs2 := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	fs2.String("key", "flag-default", "")
+	c, err = New(
+		WithConfigBuffer(strings.NewReader("key: from-file\n"), "yaml"),
+		WithAutomaticEnv(),
+		WithPFlags(fs2),
+	)
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	if got := c.String("key"); got != "from-env" {
+		t.Fatalf("String(%q) with an env var set and an unset flag = %q, want %q (env should beat the config file)", "key", got, "from-env")
+	}
+
+	if err := fs2.Set("key", "from-flag"); err != nil {
+		t.Fatalf("fs.Set() failed: %v", err)
+	}
+	c, err = New(
+		WithConfigBuffer(strings.NewReader("key: from-file\n"), "yaml"),
+		WithAutomaticEnv(),
+		WithPFlags(fs2),
+	)
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	if got := c.String("key"); got != "from-flag" {
+		t.Fatalf("String(%q) with an explicitly-set flag = %q, want %q (flag should beat both env and the config file)", "key", got, "from-flag")
+	}
+}
+
+// TestWithDotEnvFlowsThroughAutomaticEnv confirms a .env-sourced variable is
+// loaded into the process environment early enough to be picked up by
+// AutomaticEnv, overriding the config file value for the same key.
+func TestWithDotEnvFlowsThroughAutomaticEnv(t *testing.T) {
+	dir := t.TempDir()
+	envFile := filepath.Join(dir, ".env")
+	if err := os.WriteFile(envFile, []byte("KEY=from-dotenv\n"), 0o644); err != nil {
+		t.Fatalf("failed to write .env file: %v", err)
+	}
+
+	// godotenv.Load sets the process environment directly rather than
+	// through testing.T, so it must be cleaned up manually to avoid leaking
+	// KEY into other tests in this package.
+	t.Cleanup(func() { os.Unsetenv("KEY") })
+
+	c, err := New(
+		WithConfigBuffer(strings.NewReader("key: from-file\n"), "yaml"),
+		WithAutomaticEnv(),
+		WithDotEnv(envFile),
+	)
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	if got := c.String("key"); got != "from-dotenv" {
+		t.Fatalf("String(%q) = %q, want %q (.env value should flow through AutomaticEnv)", "key", got, "from-dotenv")
+	}
+}
@@ -0,0 +1,101 @@
+package configer
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// loadConfigLayers loads the base config into v and, if present, merges the
+// env-specific layer over it, returning the file paths used (both empty
+// when config came from WithConfigBuffer) along with any WithLayers
+// provenance data. It picks one of four modes:
+//
+//   - WithLayers: an arbitrary ordered list of named layers, later ones
+//     overriding earlier ones; see loadLayers.
+//   - WithConfigBuffer: read a single in-memory config, no env layer.
+//   - WithConfigPaths/WithConfigName: let viper search the given
+//     directories and auto-detect the format from its SupportedExts.
+//   - otherwise, the legacy configFilePrefix+"default."+configFileType
+//     path, preserved for backwards compatibility.
+func loadConfigLayers(v *viper.Viper, cfg *config) (defaultFile, envFile string, layerFiles []string, sources map[string][]string, err error) {
+	if len(cfg.layers) > 0 {
+		lf, src, err := loadLayers(v, cfg)
+		if err != nil {
+			return "", "", nil, nil, err
+		}
+		var first string
+		if len(lf) > 0 {
+			first = lf[0]
+		}
+		return first, "", lf, src, nil
+	}
+
+	if cfg.configBuffer != nil {
+		v.SetConfigType(cfg.configBufferFormat)
+		if err := v.ReadConfig(cfg.configBuffer); err != nil {
+			return "", "", nil, nil, fmt.Errorf("failed to read config buffer: %w", err)
+		}
+		return "", "", nil, nil, nil
+	}
+
+	if len(cfg.configPaths) > 0 || cfg.configName != "" {
+		name := cfg.configName
+		if name == "" {
+			name = "default"
+		}
+		for _, p := range cfg.configPaths {
+			v.AddConfigPath(p)
+		}
+		// Only pin the type if the caller explicitly asked to via
+		// WithConfigFileType - cfg.configFileType otherwise carries the
+		// "toml" zero-value default, which would force every discovered
+		// file to be parsed as TOML and defeat the whole point of
+		// auto-detecting the format from the file's own extension.
+		if cfg.configFileTypeSet {
+			v.SetConfigType(cfg.configFileType)
+		}
+		v.SetConfigName(name)
+
+		if err := v.ReadInConfig(); err != nil {
+			return "", "", nil, nil, fmt.Errorf("failed to load config %q: %w", name, err)
+		}
+		defaultFile = v.ConfigFileUsed()
+
+		if value, ok := os.LookupEnv(cfg.envVarName); ok {
+			v.SetConfigName(strings.ToLower(value))
+			if err := v.MergeInConfig(); err != nil {
+				if _, notFound := err.(viper.ConfigFileNotFoundError); !notFound {
+					return "", "", nil, nil, fmt.Errorf("failed to load env config %q: %w", value, err)
+				}
+			} else {
+				envFile = v.ConfigFileUsed()
+			}
+		}
+
+		return defaultFile, envFile, nil, nil, nil
+	}
+
+	v.SetConfigType(cfg.configFileType)
+	defaultFile = cfg.configFilePrefix + "default." + cfg.configFileType
+	v.SetConfigFile(defaultFile)
+
+	if err := v.ReadInConfig(); err != nil {
+		return "", "", nil, nil, fmt.Errorf("failed to load default config file: %w", err)
+	}
+
+	if value, ok := os.LookupEnv(cfg.envVarName); ok {
+		envFile = cfg.configFilePrefix + strings.ToLower(value) + "." + cfg.configFileType
+		v.SetConfigFile(envFile)
+		if err := v.MergeInConfig(); err != nil {
+			if _, notFound := err.(viper.ConfigFileNotFoundError); !notFound && !os.IsNotExist(err) {
+				return "", "", nil, nil, fmt.Errorf("failed to load env config file %s: %w", envFile, err)
+			}
+			envFile = ""
+		}
+	}
+
+	return defaultFile, envFile, nil, nil, nil
+}
@@ -0,0 +1,30 @@
+package configer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestWithConfigPathsAutoDetectsFormat guards against the type being forced
+// to the "toml" zero-value default when the caller didn't ask for it: a
+// discovered .yaml file must be parsed as YAML, not TOML.
+func TestWithConfigPathsAutoDetectsFormat(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "default.yaml"), []byte("key: value\n"), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	c, err := New(WithConfigPaths(dir), WithConfigName("default"))
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	if got := c.String("key"); got != "value" {
+		t.Fatalf("String(%q) = %q, want %q", "key", got, "value")
+	}
+	if used := c.ConfigFileUsed(); !strings.HasSuffix(used, ".yaml") {
+		t.Fatalf("ConfigFileUsed() = %q, want a .yaml file", used)
+	}
+}
@@ -0,0 +1,68 @@
+package configer
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// loadLayers loads cfg.layers into v in order, each later layer overriding
+// keys set by earlier ones, and returns the files that were actually loaded
+// (for Watch) and a key -> layer-names index (for Sources).
+func loadLayers(v *viper.Viper, cfg *config) (files []string, sources map[string][]string, err error) {
+	required := make(map[string]bool, len(cfg.requiredLayers))
+	for _, name := range cfg.requiredLayers {
+		required[name] = true
+	}
+
+	sources = make(map[string][]string)
+
+	for _, name := range cfg.layers {
+		resolved := name
+		if name == "${ENV}" {
+			value, ok := os.LookupEnv(cfg.envVarName)
+			if !ok {
+				continue
+			}
+			resolved = strings.ToLower(value)
+		}
+
+		file := cfg.configFilePrefix + resolved + "." + cfg.configFileType
+
+		layerViper := viper.New()
+		layerViper.SetConfigType(cfg.configFileType)
+		layerViper.SetConfigFile(file)
+
+		if err := layerViper.ReadInConfig(); err != nil {
+			// SetConfigFile bypasses viper's search, so a missing file comes
+			// back as a not-exist error rather than ConfigFileNotFoundError.
+			_, notFound := err.(viper.ConfigFileNotFoundError)
+			if (notFound || os.IsNotExist(err)) && !required[name] {
+				continue
+			}
+			return nil, nil, fmt.Errorf("failed to load layer %q (%s): %w", name, file, err)
+		}
+
+		if len(files) == 0 {
+			v.SetConfigType(cfg.configFileType)
+			v.SetConfigFile(file)
+			if err := v.ReadInConfig(); err != nil {
+				return nil, nil, fmt.Errorf("failed to load layer %q (%s): %w", name, file, err)
+			}
+		} else {
+			v.SetConfigFile(file)
+			if err := v.MergeInConfig(); err != nil {
+				return nil, nil, fmt.Errorf("failed to merge layer %q (%s): %w", name, file, err)
+			}
+		}
+		files = append(files, file)
+
+		for key := range flattenSettings(layerViper.AllSettings()) {
+			sources[key] = append(sources[key], name)
+		}
+	}
+
+	return files, sources, nil
+}
@@ -0,0 +1,57 @@
+package configer
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestWithLayersPrecedenceAndSources(t *testing.T) {
+	dir := t.TempDir()
+	prefix := dir + string(os.PathSeparator)
+
+	if err := os.WriteFile(filepath.Join(dir, "default.toml"), []byte("key = \"base\"\nother = \"x\"\n"), 0o644); err != nil {
+		t.Fatalf("failed to write default layer: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "local.toml"), []byte("key = \"override\"\n"), 0o644); err != nil {
+		t.Fatalf("failed to write local layer: %v", err)
+	}
+
+	c, err := New(
+		WithEnvConfigFilePrefix(prefix),
+		WithLayers("default", "local"),
+		WithRequiredLayers("default"),
+	)
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	if got := c.String("key"); got != "override" {
+		t.Fatalf("String(%q) = %q, want %q (local layer should win)", "key", got, "override")
+	}
+	if got := c.String("other"); got != "x" {
+		t.Fatalf("String(%q) = %q, want %q (from default layer)", "other", got, "x")
+	}
+
+	if got := c.Sources("key"); !reflect.DeepEqual(got, []string{"default", "local"}) {
+		t.Fatalf("Sources(%q) = %v, want [default local]", "key", got)
+	}
+	if got := c.Sources("other"); !reflect.DeepEqual(got, []string{"default"}) {
+		t.Fatalf("Sources(%q) = %v, want [default]", "other", got)
+	}
+}
+
+func TestWithLayersRequiredLayerMissingErrors(t *testing.T) {
+	dir := t.TempDir()
+	prefix := dir + string(os.PathSeparator)
+
+	_, err := New(
+		WithEnvConfigFilePrefix(prefix),
+		WithLayers("default"),
+		WithRequiredLayers("default"),
+	)
+	if err == nil {
+		t.Fatal("New() succeeded, want an error for a missing required layer")
+	}
+}
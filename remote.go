@@ -0,0 +1,97 @@
+package configer
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/viper"
+	_ "github.com/spf13/viper/remote"
+)
+
+// applyRemoteConfig reads cfg's remote provider (if any) into its own viper
+// instance and merges it over v, giving remote values precedence over the
+// file-based config already loaded into v. It returns the remote viper so
+// the caller can keep polling it, or (nil, nil) if no remote provider is
+// configured.
+func applyRemoteConfig(v *viper.Viper, cfg *config) (*viper.Viper, error) {
+	if cfg.remoteProvider == "" {
+		return nil, nil
+	}
+
+	rv := viper.New()
+	rv.SetConfigType(cfg.configFileType)
+
+	var err error
+	if cfg.remoteSecretKeyring != "" {
+		err = rv.AddSecureRemoteProvider(cfg.remoteProvider, cfg.remoteEndpoint, cfg.remotePath, cfg.remoteSecretKeyring)
+	} else {
+		err = rv.AddRemoteProvider(cfg.remoteProvider, cfg.remoteEndpoint, cfg.remotePath)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure remote provider %s: %w", cfg.remoteProvider, err)
+	}
+
+	if err := rv.ReadRemoteConfig(); err != nil {
+		return nil, fmt.Errorf("failed to read remote config from %s: %w", cfg.remoteProvider, err)
+	}
+
+	if err := v.MergeConfigMap(rv.AllSettings()); err != nil {
+		return nil, fmt.Errorf("failed to merge remote config: %w", err)
+	}
+
+	return rv, nil
+}
+
+// startRemoteWatch polls the remote provider on the configured interval,
+// re-merging any changes over the current file-based config. It's started
+// automatically by New when WithRemoteProvider is set, and stopped by Close.
+func (c *configer) startRemoteWatch() {
+	c.mu.Lock()
+	closed := make(chan struct{})
+	c.remoteClosed = closed
+	c.mu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(c.cfg.remotePollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				c.pollRemote()
+			case <-closed:
+				return
+			}
+		}
+	}()
+}
+
+func (c *configer) pollRemote() {
+	c.mu.RLock()
+	rv := c.remoteViper
+	c.mu.RUnlock()
+
+	if rv == nil {
+		return
+	}
+
+	// ReadRemoteConfig is a synchronous, one-shot fetch, unlike
+	// WatchRemoteConfigOnChannel which spawns its own never-stopped
+	// goroutine parked on a fresh subscription every time it's called -
+	// calling it once per tick here would leak one goroutine per interval
+	// for the life of the process.
+	if err := rv.ReadRemoteConfig(); err != nil {
+		// Keep serving the last known-good remote values on a transient
+		// provider error rather than tearing anything down.
+		return
+	}
+
+	c.mu.Lock()
+	// c.remoteViper may have been swapped by a concurrent reload (chunk0-1);
+	// only merge if rv is still the current one, and merge into the current
+	// c.viper rather than a pointer captured before the lock.
+	if c.remoteViper == rv {
+		c.viper.MergeConfigMap(rv.AllSettings())
+	}
+	c.mu.Unlock()
+}
@@ -0,0 +1,117 @@
+package configer
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+// newFileViper builds a viper instance loaded from an in-memory config
+// buffer, mirroring how buildViper's file layer is actually populated - as
+// opposed to v.Set, which writes to viper's override layer and would always
+// win over a later MergeConfigMap regardless of the guard being tested.
+func newFileViper(t *testing.T, yaml string) *viper.Viper {
+	t.Helper()
+
+	v := viper.New()
+	v.SetConfigType("yaml")
+	if err := v.ReadConfig(strings.NewReader(yaml)); err != nil {
+		t.Fatalf("ReadConfig() failed: %v", err)
+	}
+	return v
+}
+
+// TestNewRejectsUnsupportedRemoteProvider guards the error path in
+// applyRemoteConfig: an unrecognized provider name must surface viper's own
+// "Unsupported Remote Provider Type" error from New, not a panic or a
+// silently-empty config.
+func TestNewRejectsUnsupportedRemoteProvider(t *testing.T) {
+	_, err := New(
+		WithConfigBuffer(strings.NewReader("key: value\n"), "yaml"),
+		WithRemoteProvider("bogus", "http://127.0.0.1:0", "/config"),
+	)
+	if err == nil {
+		t.Fatal("New() succeeded, want an error for an unsupported remote provider")
+	}
+	if !strings.Contains(err.Error(), "Unsupported Remote Provider Type") {
+		t.Fatalf("New() error = %q, want it to contain %q", err.Error(), "Unsupported Remote Provider Type")
+	}
+}
+
+// fakeRemoteConfig stands in for viper's real remote backend (etcd/Consul/
+// etc.) so pollRemote can be exercised without a live remote store. get is
+// invoked synchronously from within ReadRemoteConfig, which lets tests
+// simulate state changes (like a concurrent reload swapping c.remoteViper)
+// that happen while a fetch is in flight.
+type fakeRemoteConfig struct {
+	get func() []byte
+}
+
+func (f fakeRemoteConfig) Get(rp viper.RemoteProvider) (io.Reader, error) {
+	return bytes.NewReader(f.get()), nil
+}
+
+func (f fakeRemoteConfig) Watch(rp viper.RemoteProvider) (io.Reader, error) {
+	return bytes.NewReader(f.get()), nil
+}
+
+func (f fakeRemoteConfig) WatchChannel(rp viper.RemoteProvider) (<-chan *viper.RemoteResponse, chan bool) {
+	return nil, nil
+}
+
+// newFakeRemoteViper builds a remoteViper wired to a fake remote backend
+// that returns data() whenever it's read.
+func newFakeRemoteViper(t *testing.T, data func() []byte) *viper.Viper {
+	t.Helper()
+
+	original := viper.RemoteConfig
+	viper.RemoteConfig = fakeRemoteConfig{get: data}
+	t.Cleanup(func() { viper.RemoteConfig = original })
+
+	rv := viper.New()
+	rv.SetConfigType("yaml")
+	if err := rv.AddRemoteProvider("etcd3", "stub", "/config"); err != nil {
+		t.Fatalf("AddRemoteProvider() failed: %v", err)
+	}
+	return rv
+}
+
+// TestPollRemoteMergesCurrentRemoteViper confirms the ordinary case: when
+// c.remoteViper hasn't been swapped out from under a poll, its settings
+// merge over the file config.
+func TestPollRemoteMergesCurrentRemoteViper(t *testing.T) {
+	c := &configer{viper: newFileViper(t, "key: from-file\n")}
+	c.remoteViper = newFakeRemoteViper(t, func() []byte { return []byte("key: from-remote\n") })
+
+	c.pollRemote()
+
+	if got := c.viper.GetString("key"); got != "from-remote" {
+		t.Fatalf("String(%q) = %q, want %q (current remoteViper should merge)", "key", got, "from-remote")
+	}
+}
+
+// TestPollRemoteSkipsMergeAfterConcurrentSwap guards the rv == c.remoteViper
+// identity check in pollRemote: if reload() swaps in a new remoteViper while
+// a poll against the old one is still in flight, the stale poll's result
+// must not be merged over (and so silently revert) the fresh file config.
+func TestPollRemoteSkipsMergeAfterConcurrentSwap(t *testing.T) {
+	c := &configer{viper: newFileViper(t, "key: from-file\n")}
+
+	fresh := viper.New()
+	stale := newFakeRemoteViper(t, func() []byte {
+		// Simulate reload() swapping in a new remoteViper while this fetch
+		// (started against stale) is still in flight.
+		c.remoteViper = fresh
+		return []byte("key: from-stale-remote\n")
+	})
+	c.remoteViper = stale
+
+	c.pollRemote()
+
+	if got := c.viper.GetString("key"); got != "from-file" {
+		t.Fatalf("String(%q) = %q, want %q (stale remoteViper must not merge after being swapped out)", "key", got, "from-file")
+	}
+}
@@ -0,0 +1,249 @@
+package configer
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/mitchellh/mapstructure"
+)
+
+// validate is shared across all Unmarshal calls; it's safe for concurrent
+// use and caches struct field metadata internally.
+var validate = validator.New()
+
+// unmarshalConfig holds the options accumulated from UnmarshalOption values.
+type unmarshalConfig struct {
+	decodeHooks []mapstructure.DecodeHookFunc
+	validate    bool
+}
+
+// UnmarshalOption customizes a single Unmarshal/UnmarshalKey call.
+type UnmarshalOption func(*unmarshalConfig)
+
+// WithDecodeHook appends an additional mapstructure decode hook, run after
+// the hooks Unmarshal installs by default (duration, time, URL and
+// CSV-to-string-slice).
+func WithDecodeHook(hook mapstructure.DecodeHookFunc) UnmarshalOption {
+	return func(c *unmarshalConfig) {
+		c.decodeHooks = append(c.decodeHooks, hook)
+	}
+}
+
+// WithValidate runs go-playground/validator against target's `validate`
+// struct tags after decoding, returning an aggregated error if any field
+// fails.
+func WithValidate() UnmarshalOption {
+	return func(c *unmarshalConfig) {
+		c.validate = true
+	}
+}
+
+func defaultUnmarshalConfig() *unmarshalConfig {
+	return &unmarshalConfig{
+		decodeHooks: []mapstructure.DecodeHookFunc{
+			mapstructure.StringToTimeDurationHookFunc(),
+			mapstructure.StringToTimeHookFunc(time.RFC3339),
+			stringToURLHookFunc(),
+			mapstructure.StringToSliceHookFunc(","),
+		},
+	}
+}
+
+// Unmarshal decodes the entire config into target, a pointer to a struct.
+// Fields tagged `default:"..."` are populated before decoding so that a key
+// missing from every layer still ends up with a sane value, and fields
+// tagged `validate:"..."` are checked via go-playground/validator when
+// WithValidate is given.
+func (c *configer) Unmarshal(target any, opts ...UnmarshalOption) error {
+	return c.unmarshalKey("", target, opts...)
+}
+
+// UnmarshalKey decodes the value at key into target, a pointer to a struct,
+// applying the same default and validate tag handling as Unmarshal.
+func (c *configer) UnmarshalKey(key string, target any, opts ...UnmarshalOption) error {
+	return c.unmarshalKey(key, target, opts...)
+}
+
+func (c *configer) unmarshalKey(key string, target any, opts ...UnmarshalOption) error {
+	if err := applyDefaults(target); err != nil {
+		return fmt.Errorf("failed to apply default tags: %w", err)
+	}
+
+	cfg := defaultUnmarshalConfig()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	decoderConfig := &mapstructure.DecoderConfig{
+		Result:           target,
+		WeaklyTypedInput: true,
+		DecodeHook:       mapstructure.ComposeDecodeHookFunc(cfg.decodeHooks...),
+		TagName:          "mapstructure",
+	}
+	decoder, err := mapstructure.NewDecoder(decoderConfig)
+	if err != nil {
+		return fmt.Errorf("failed to build decoder: %w", err)
+	}
+
+	c.mu.RLock()
+	var raw any
+	if key == "" {
+		raw = c.viper.AllSettings()
+	} else {
+		raw = c.viper.Get(key)
+	}
+	c.mu.RUnlock()
+
+	if err := decoder.Decode(raw); err != nil {
+		return fmt.Errorf("failed to decode config: %w", err)
+	}
+
+	if cfg.validate {
+		if err := validate.Struct(target); err != nil {
+			return fmt.Errorf("config validation failed: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// applyDefaults walks target's fields and sets any zero-valued field that
+// carries a `default:"..."` tag, before mapstructure has a chance to
+// overwrite it with an actual config value.
+func applyDefaults(target any) error {
+	v := reflect.ValueOf(target)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("target must be a pointer to a struct")
+	}
+	return applyDefaultsValue(v.Elem())
+}
+
+var (
+	timeType = reflect.TypeOf(time.Time{})
+	urlType  = reflect.TypeOf(url.URL{})
+)
+
+func applyDefaultsValue(v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fv := v.Field(i)
+		if !fv.CanSet() {
+			continue
+		}
+
+		tag, hasTag := field.Tag.Lookup("default")
+		if hasTag {
+			if fv.IsZero() {
+				if err := setDefaultValue(fv, tag); err != nil {
+					return fmt.Errorf("field %s: %w", field.Name, err)
+				}
+			}
+			continue
+		}
+
+		// Recurse into plain nested structs for their own default tags, but
+		// not into struct types that are themselves leaf values (time.Time,
+		// url.URL) - recursing into those only reaches their unexported
+		// internal fields, silently dropping a `default` tag placed on the
+		// field itself.
+		if fv.Kind() == reflect.Struct && fv.Type() != timeType && fv.Type() != urlType {
+			if err := applyDefaultsValue(fv); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func setDefaultValue(fv reflect.Value, raw string) error {
+	switch {
+	case fv.Type() == timeType:
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return err
+		}
+		fv.Set(reflect.ValueOf(t))
+		return nil
+	case fv.Type() == urlType:
+		u, err := url.Parse(raw)
+		if err != nil {
+			return err
+		}
+		fv.Set(reflect.ValueOf(*u))
+		return nil
+	}
+
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(raw)
+	case reflect.Bool:
+		fv.SetBool(raw == "true")
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if fv.Type() == reflect.TypeOf(time.Duration(0)) {
+			d, err := time.ParseDuration(raw)
+			if err != nil {
+				return err
+			}
+			fv.SetInt(int64(d))
+			return nil
+		}
+		var n int64
+		if _, err := fmt.Sscanf(raw, "%d", &n); err != nil {
+			return err
+		}
+		fv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var n uint64
+		if _, err := fmt.Sscanf(raw, "%d", &n); err != nil {
+			return err
+		}
+		fv.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		if _, err := fmt.Sscanf(raw, "%g", &f); err != nil {
+			return err
+		}
+		fv.SetFloat(f)
+	case reflect.Slice:
+		if fv.Type().Elem().Kind() == reflect.String {
+			fv.Set(reflect.ValueOf(strings.Split(raw, ",")))
+			return nil
+		}
+		return fmt.Errorf("unsupported default for slice of %s", fv.Type().Elem())
+	default:
+		return fmt.Errorf("unsupported default for kind %s", fv.Kind())
+	}
+	return nil
+}
+
+// stringToURLHookFunc decodes config strings into *url.URL and url.URL
+// fields.
+func stringToURLHookFunc() mapstructure.DecodeHookFunc {
+	return func(from reflect.Type, to reflect.Type, data any) (any, error) {
+		if from.Kind() != reflect.String {
+			return data, nil
+		}
+
+		switch {
+		case to == urlType:
+			u, err := url.Parse(data.(string))
+			if err != nil {
+				return nil, err
+			}
+			return *u, nil
+		case to == reflect.PtrTo(urlType):
+			u, err := url.Parse(data.(string))
+			if err != nil {
+				return nil, err
+			}
+			return u, nil
+		default:
+			return data, nil
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package configer
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type testAppConfig struct {
+	Name    string        `mapstructure:"name" validate:"required"`
+	Port    int           `mapstructure:"port" default:"8080"`
+	Timeout time.Duration `mapstructure:"timeout" default:"5s"`
+}
+
+func TestUnmarshalAppliesDefaults(t *testing.T) {
+	c, err := New(WithConfigBuffer(strings.NewReader("name: svc\n"), "yaml"))
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	var cfg testAppConfig
+	if err := c.Unmarshal(&cfg); err != nil {
+		t.Fatalf("Unmarshal() failed: %v", err)
+	}
+
+	if cfg.Name != "svc" {
+		t.Errorf("Name = %q, want %q", cfg.Name, "svc")
+	}
+	if cfg.Port != 8080 {
+		t.Errorf("Port = %d, want %d (from default tag)", cfg.Port, 8080)
+	}
+	if cfg.Timeout != 5*time.Second {
+		t.Errorf("Timeout = %v, want %v (from default tag)", cfg.Timeout, 5*time.Second)
+	}
+}
+
+func TestUnmarshalWithValidateRejectsMissingRequiredField(t *testing.T) {
+	c, err := New(WithConfigBuffer(strings.NewReader("port: 9090\n"), "yaml"))
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	var cfg testAppConfig
+	if err := c.Unmarshal(&cfg, WithValidate()); err == nil {
+		t.Fatal("Unmarshal() with WithValidate succeeded, want an error for missing required name")
+	}
+}
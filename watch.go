@@ -0,0 +1,204 @@
+package configer
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// ChangeHandler is invoked by Watch for every key whose value changed after
+// a config reload. newVal is nil when the key was removed from the reloaded
+// config.
+type ChangeHandler func(key string, oldVal, newVal any)
+
+// Watch starts watching every file that went into the loaded config (the
+// default and env-specific files, or the layer files from WithLayers) for
+// filesystem changes. On every write it rebuilds the underlying viper
+// instance from scratch, diffs it against the previous one, and fires any
+// registered OnChange callbacks for the keys that moved. Calling Watch again
+// while already watching is a no-op.
+func (c *configer) Watch() error {
+	c.mu.Lock()
+	if c.watcher != nil {
+		c.mu.Unlock()
+		return nil
+	}
+
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		c.mu.Unlock()
+		return fmt.Errorf("failed to create config watcher: %w", err)
+	}
+
+	// Watch containing directories rather than the files themselves. Editors
+	// and tools that save atomically via rename (vim, most ops tooling,
+	// k8s ConfigMap projections) fire CHMOD/REMOVE on the old path, after
+	// which a watch on that exact path never sees another event. viper's own
+	// WatchConfig watches the directory and filters by filename for the same
+	// reason.
+	dirs := make(map[string]bool)
+	watchSet := make(map[string]bool)
+	for _, file := range c.watchedFiles() {
+		dirs[filepath.Dir(file)] = true
+		watchSet[filepath.Clean(file)] = true
+	}
+	for dir := range dirs {
+		if err := w.Add(dir); err != nil {
+			w.Close()
+			c.mu.Unlock()
+			return fmt.Errorf("failed to watch config directory %s: %w", dir, err)
+		}
+	}
+
+	c.watcher = w
+	c.closed = make(chan struct{})
+	c.mu.Unlock()
+
+	go c.watchLoop(w, c.closed, watchSet)
+	return nil
+}
+
+// watchedFiles returns the config files Watch should add to the fsnotify
+// watcher. Callers must hold c.mu.
+func (c *configer) watchedFiles() []string {
+	if len(c.layerFiles) > 0 {
+		return c.layerFiles
+	}
+	var files []string
+	if c.defaultFile != "" {
+		files = append(files, c.defaultFile)
+	}
+	if c.envFile != "" {
+		files = append(files, c.envFile)
+	}
+	return files
+}
+
+// OnChange registers fn to be called whenever Watch detects that a key's
+// value changed. Handlers run synchronously on the watch goroutine, so they
+// should not block for long. OnChange can be called before or after Watch.
+func (c *configer) OnChange(fn ChangeHandler) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onChange = append(c.onChange, fn)
+}
+
+// Close stops a running watch, including any remote provider polling
+// started for a WithRemoteProvider config. It is safe to call even if Watch
+// was never called, and safe to call more than once.
+func (c *configer) Close() error {
+	c.mu.Lock()
+	w := c.watcher
+	closed := c.closed
+	remoteClosed := c.remoteClosed
+	c.watcher = nil
+	c.closed = nil
+	c.remoteClosed = nil
+	c.mu.Unlock()
+
+	if remoteClosed != nil {
+		close(remoteClosed)
+	}
+
+	if w == nil {
+		return nil
+	}
+	close(closed)
+	return w.Close()
+}
+
+func (c *configer) watchLoop(w *fsnotify.Watcher, closed chan struct{}, watchSet map[string]bool) {
+	for {
+		select {
+		case event, ok := <-w.Events:
+			if !ok {
+				return
+			}
+			if !watchSet[filepath.Clean(event.Name)] {
+				continue
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Remove|fsnotify.Rename|fsnotify.Chmod) != 0 {
+				c.reload()
+			}
+		case _, ok := <-w.Errors:
+			if !ok {
+				return
+			}
+		case <-closed:
+			return
+		}
+	}
+}
+
+// reload rebuilds the viper instance from the original options, swaps it in
+// along with the layer/remote bookkeeping that goes with it, and fires
+// OnChange for every key whose value differs from before.
+func (c *configer) reload() {
+	c.mu.RLock()
+	cfg := c.cfg
+	oldViper := c.viper
+	c.mu.RUnlock()
+
+	newViper, _, _, layerFiles, sources, remoteViper, err := buildViper(cfg)
+	if err != nil {
+		// Keep serving the last good config rather than tearing it down on a
+		// transient read error (e.g. the file briefly missing mid-write).
+		return
+	}
+
+	oldSettings := flattenSettings(oldViper.AllSettings())
+	newSettings := flattenSettings(newViper.AllSettings())
+
+	c.mu.Lock()
+	c.viper = newViper
+	// Keep layerFiles/sources/remoteViper in lockstep with the rebuilt
+	// viper: Sources must reflect the config that's actually being served,
+	// and the background remote poll (chunk0-3) must merge on top of this
+	// fresh file-based config rather than an older one, or it can silently
+	// revert remote-sourced values on its next tick.
+	c.layerFiles = layerFiles
+	c.sources = sources
+	c.remoteViper = remoteViper
+	handlers := append([]ChangeHandler(nil), c.onChange...)
+	c.mu.Unlock()
+
+	for key, newVal := range newSettings {
+		if oldVal, ok := oldSettings[key]; !ok || !reflect.DeepEqual(oldVal, newVal) {
+			for _, h := range handlers {
+				h(key, oldSettings[key], newVal)
+			}
+		}
+	}
+	for key, oldVal := range oldSettings {
+		if _, ok := newSettings[key]; !ok {
+			for _, h := range handlers {
+				h(key, oldVal, nil)
+			}
+		}
+	}
+}
+
+// flattenSettings turns viper's nested AllSettings() map into a flat
+// map keyed by dotted path (e.g. "database.host"), matching the key format
+// accepted by the Configer getters.
+func flattenSettings(m map[string]any) map[string]any {
+	out := make(map[string]any)
+	var walk func(prefix string, v map[string]any)
+	walk = func(prefix string, v map[string]any) {
+		for k, val := range v {
+			key := k
+			if prefix != "" {
+				key = prefix + "." + k
+			}
+			if nested, ok := val.(map[string]any); ok {
+				walk(key, nested)
+			} else {
+				out[key] = val
+			}
+		}
+	}
+	walk("", m)
+	return out
+}
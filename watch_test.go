@@ -0,0 +1,66 @@
+package configer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestWatchOnChange exercises the Watch/OnChange round trip end to end,
+// including the atomic rename-based save pattern editors and ops tooling
+// actually use (write to a sibling temp file, then rename over the target).
+func TestWatchOnChange(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "default.toml")
+	if err := os.WriteFile(target, []byte("key = \"v1\"\n"), 0o644); err != nil {
+		t.Fatalf("failed to write initial config: %v", err)
+	}
+
+	c, err := New(WithEnvConfigFilePrefix(dir + string(os.PathSeparator)))
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	if got := c.String("key"); got != "v1" {
+		t.Fatalf("String(%q) = %q, want %q", "key", got, "v1")
+	}
+
+	if err := c.Watch(); err != nil {
+		t.Fatalf("Watch() failed: %v", err)
+	}
+	defer c.Close()
+
+	changed := make(chan string, 1)
+	c.OnChange(func(key string, oldVal, newVal any) {
+		if key == "key" {
+			select {
+			case changed <- newVal.(string):
+			default:
+			}
+		}
+	})
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		tmp := filepath.Join(dir, "default.toml.tmp")
+		if err := os.WriteFile(tmp, []byte("key = \"v2\"\n"), 0o644); err != nil {
+			t.Fatalf("failed to write replacement config: %v", err)
+		}
+		if err := os.Rename(tmp, target); err != nil {
+			t.Fatalf("failed to rename replacement config into place: %v", err)
+		}
+
+		select {
+		case newVal := <-changed:
+			if newVal != "v2" {
+				t.Fatalf("OnChange fired with newVal = %q, want %q", newVal, "v2")
+			}
+			if got := c.String("key"); got != "v2" {
+				t.Fatalf("String(%q) after reload = %q, want %q", "key", got, "v2")
+			}
+			return
+		case <-time.After(200 * time.Millisecond):
+		}
+	}
+	t.Fatal("timed out waiting for OnChange after an atomic-rename save")
+}